@@ -0,0 +1,187 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Dependency resolution: reads the [dependencies] table from bituin.toml,
+ * resolves a deterministic install order, and drives the Registry +
+ * Lockfile to install packages into microscript_modules/.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const REGISTRY_URL_ENV = "BITUIN_REGISTRY_URL"
+
+var dependenciesSectionRe = regexp.MustCompile(`(?ms)^\[dependencies\]\s*\n(.*?)(\n\[|\z)`)
+var dependencyLineRe = regexp.MustCompile(`(?m)^\s*([\w-]+)\s*=\s*"([^"]+)"\s*$`)
+
+// ReadDependencies extracts the [dependencies] table from bituin.toml as
+// a map of package name to semver constraint (e.g. "^1.2.0").
+func ReadDependencies(configContent string) map[string]string {
+	deps := map[string]string{}
+
+	section := dependenciesSectionRe.FindStringSubmatch(configContent)
+	if section == nil {
+		return deps
+	}
+
+	for _, line := range dependencyLineRe.FindAllStringSubmatch(section[1], -1) {
+		deps[line[1]] = line[2]
+	}
+	return deps
+}
+
+// AddDependency inserts or updates name's constraint in bituin.toml's
+// [dependencies] table, creating the table if it doesn't exist yet.
+func AddDependency(configContent, name, constraint string) string {
+	entry := fmt.Sprintf("%s = %q", name, constraint)
+
+	if section := dependenciesSectionRe.FindStringSubmatch(configContent); section != nil {
+		body := section[1]
+		replaced := false
+		lines := strings.Split(body, "\n")
+		for i, line := range lines {
+			if m := dependencyLineRe.FindStringSubmatch(line); m != nil && m[1] == name {
+				lines[i] = entry
+				replaced = true
+			}
+		}
+		if !replaced {
+			lines = append(lines, entry)
+		}
+		newBody := strings.Join(lines, "\n")
+		return strings.Replace(configContent, "[dependencies]\n"+body, "[dependencies]\n"+newBody, 1)
+	}
+
+	if !strings.HasSuffix(configContent, "\n") {
+		configContent += "\n"
+	}
+	return configContent + fmt.Sprintf("\n[dependencies]\n%s\n", entry)
+}
+
+// installOrder returns deps' names in a deterministic (sorted) order.
+// bituin.toml's [dependencies] table declares no transitive edges today,
+// so there is no dependency graph to topologically sort or check for
+// cycles yet - this just makes install order reproducible across runs.
+// Once packages can declare their own dependencies, real edges and
+// cycle detection belong here.
+func installOrder(deps map[string]string) []string {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// InstallDependencies installs every package in deps, writing the
+// result into bituin.lock, shared at the workspace root when
+// projectRoot is a workspace member (matching installPathDependencies).
+// A name whose existing lockfile entry already satisfies its constraint
+// keeps its pinned version instead of being re-resolved against the
+// registry (re-fetched at that version and checked against its pinned
+// SHA-256 when it isn't unpacked yet), so that adding one new
+// dependency doesn't silently upgrade every other already-pinned
+// package. When offline is true nothing is fetched: every package must
+// already have a satisfying lockfile entry and be unpacked under
+// microscript_modules/, otherwise an error is returned.
+func InstallDependencies(projectRoot string, deps map[string]string, offline bool) error {
+	lockRoot := projectRoot
+	if root, ok := FindWorkspaceRoot(projectRoot); ok {
+		lockRoot = root
+	}
+
+	lock, err := ReadLockfile(lockRoot)
+	if err != nil {
+		return err
+	}
+
+	registry := NewRegistry(os.Getenv(REGISTRY_URL_ENV))
+
+	for _, name := range installOrder(deps) {
+		constraint := deps[name]
+		locked := findLocked(lock, name)
+
+		if locked != nil {
+			if satisfiesLocked(*locked, constraint) {
+				if err := checkUnpacked(projectRoot, name, locked.Version); err == nil {
+					fmt.Printf("Using pinned %s@%s\n", name, locked.Version)
+					continue
+				} else if offline {
+					return err
+				}
+				data, _, err := registry.Fetch(name, locked.Version)
+				if err == nil {
+					err = VerifyChecksum(data, locked.SHA256)
+				}
+				if err == nil {
+					err = Unpack(projectRoot, name, locked.Version, data)
+				}
+				if err != nil {
+					return fmt.Errorf("restoring pinned %s@%s: %w", name, locked.Version, err)
+				}
+				fmt.Printf("Restored pinned %s@%s\n", name, locked.Version)
+				continue
+			} else if offline {
+				return fmt.Errorf("offline mode: locked %s@%s no longer satisfies %q", name, locked.Version, constraint)
+			}
+		}
+
+		if offline {
+			return fmt.Errorf("offline mode: %q is not present in %s", name, LOCKFILE_NAME)
+		}
+
+		fmt.Printf("Resolving %s%s...\n", name, constraint)
+		pkg, err := registry.Install(projectRoot, name, constraint, false)
+		if err != nil {
+			return err
+		}
+		lock.Put(*pkg)
+		fmt.Printf("Installed %s@%s\n", pkg.Name, pkg.Version)
+	}
+
+	return lock.Write(lockRoot)
+}
+
+// satisfiesLocked reports whether a locked package's pinned version
+// still satisfies constraint, so InstallDependencies can skip
+// re-resolving it.
+func satisfiesLocked(locked LockedPackage, constraint string) bool {
+	v, err := ParseSemVer(locked.Version)
+	if err != nil {
+		return false
+	}
+	ok, err := v.Satisfies(constraint)
+	return err == nil && ok
+}
+
+func findLocked(lock *Lockfile, name string) *LockedPackage {
+	for i := range lock.Packages {
+		if lock.Packages[i].Name == name {
+			return &lock.Packages[i]
+		}
+	}
+	return nil
+}
+
+// checkUnpacked confirms that name@version is already present under
+// microscript_modules/, which is all offline mode can verify without
+// re-fetching the original tarball to re-hash it.
+func checkUnpacked(projectRoot, name, version string) error {
+	dir := projectRoot + string(os.PathSeparator) + MODULES_DIR + string(os.PathSeparator) + name + string(os.PathSeparator) + version
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("%s@%s not found in %s (run without --offline first)", name, version, MODULES_DIR)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * bituin.lock pins the exact, content-addressed versions resolved for a
+ * project's dependencies so installs are reproducible.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const LOCKFILE_NAME = "bituin.lock"
+
+// LockedPackage is one resolved, hash-pinned dependency.
+type LockedPackage struct {
+	Name    string
+	Version string
+	SHA256  string
+}
+
+// Lockfile is the parsed contents of bituin.lock.
+type Lockfile struct {
+	Packages []LockedPackage
+}
+
+var lockPackageRe = regexp.MustCompile(`(?m)^\[\[package\]\]\s*\nname\s*=\s*"([^"]+)"\s*\nversion\s*=\s*"([^"]+)"\s*\nsha256\s*=\s*"([^"]+)"`)
+
+// ReadLockfile loads bituin.lock from projectRoot. A missing lockfile is
+// not an error; it simply yields an empty Lockfile.
+func ReadLockfile(projectRoot string) (*Lockfile, error) {
+	path := projectRoot + string(os.PathSeparator) + LOCKFILE_NAME
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Lockfile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", LOCKFILE_NAME, err)
+	}
+
+	lock := &Lockfile{}
+	for _, match := range lockPackageRe.FindAllStringSubmatch(string(data), -1) {
+		lock.Packages = append(lock.Packages, LockedPackage{
+			Name:    match[1],
+			Version: match[2],
+			SHA256:  match[3],
+		})
+	}
+	return lock, nil
+}
+
+// Put inserts or replaces the locked entry for a package name.
+func (l *Lockfile) Put(pkg LockedPackage) {
+	for i, existing := range l.Packages {
+		if existing.Name == pkg.Name {
+			l.Packages[i] = pkg
+			return
+		}
+	}
+	l.Packages = append(l.Packages, pkg)
+}
+
+// Write serializes the lockfile to bituin.lock, sorted by package name so
+// the output is stable across runs.
+func (l *Lockfile) Write(projectRoot string) error {
+	sorted := make([]LockedPackage, len(l.Packages))
+	copy(sorted, l.Packages)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	b.WriteString("# This file is automatically generated by bituin.\n")
+	b.WriteString("# It is not intended for manual editing.\n\n")
+	for _, pkg := range sorted {
+		b.WriteString("[[package]]\n")
+		fmt.Fprintf(&b, "name = %q\n", pkg.Name)
+		fmt.Fprintf(&b, "version = %q\n", pkg.Version)
+		fmt.Fprintf(&b, "sha256 = %q\n\n", pkg.SHA256)
+	}
+
+	path := projectRoot + string(os.PathSeparator) + LOCKFILE_NAME
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", LOCKFILE_NAME, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("committing %s: %w", LOCKFILE_NAME, err)
+	}
+	return nil
+}
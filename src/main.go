@@ -13,6 +13,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -28,60 +29,39 @@ const (
 	INIT        = "init"
 	RUN         = "run"
 	ADD         = "add"
+	TEST        = "test"
+	BUILD       = "build"
+	FMT         = "fmt"
+	PACKAGE     = "package"
+	PUBLISH     = "publish"
+	LOGIN       = "login"
+	TOOLCHAIN   = "toolchain"
 	HELP        = "help"
 	VERSION_CMD = "version"
 	AUTHOR_CMD  = "author"
 )
 
-// Templates
-const MAIN_MICROSCRIPT = `function main() {
-    console.write("Hello, World!");
-}
-
-main();`
-
-func getBituinToml(projectName string) string {
-	return fmt.Sprintf(`[package]
-name = "%s"
-main_file = "src/main.microscript"`, projectName)
-}
-
 func printUsage() {
 	fmt.Println("\033[32mUsage:\033[0m")
-	fmt.Println("  \033[34mnew\033[0m [project_name]  - Create a new bituin package in a new directory")
-	fmt.Println("  \033[34minit\033[0m [project_name] - Create a new bituin package in an existing directory")
-	fmt.Println("  \033[34madd\033[0m [filename]      - Create a new MicroScript source file")
-	fmt.Println("  \033[34mrun\033[0m [--preview] [filename] - Run the current project (optionally in preview mode)")
+	fmt.Println("  \033[34mnew\033[0m [project_name] [--template t] [--author a] [--license l] [--git] - Create a new bituin package in a new directory")
+	fmt.Println("  \033[34mnew\033[0m --list-templates - List available project templates")
+	fmt.Println("  \033[34minit\033[0m [project_name] [--template t] [--author a] [--license l] [--git] - Create a new bituin package in an existing directory")
+	fmt.Println("  \033[34madd\033[0m [name[@version]] [--offline] - Add a package dependency and install it")
+	fmt.Println("  \033[34madd\033[0m --file [filename]  - Create a new MicroScript source file")
+	fmt.Println("  \033[34mrun\033[0m [--preview] [-p member] [filename] - Run the current project (optionally in preview mode)")
+	fmt.Println("  \033[34mtest\033[0m [-n N] [-shard I/M] [-v] [-k] [--workspace] - Run *.test.microscript files under tests/")
+	fmt.Println("  \033[34mbuild\033[0m            - Build the current project, or every workspace member")
+	fmt.Println("  \033[34mfmt\033[0m [--workspace] - Format the current project (hook point for a future formatter)")
+	fmt.Println("  \033[34mpackage\033[0m          - Produce a reproducible dist/<name>-<version>.bituin archive (signed if [package].signing_key is set)")
+	fmt.Println("  \033[34mpublish\033[0m          - Publish the current project's package to the registry")
+	fmt.Println("  \033[34mlogin\033[0m            - Save a registry token to ~/.bituin/credentials.toml")
+	fmt.Println("  \033[34mtoolchain\033[0m install <version> [--sha256 <hex>] - Download and cache an interpreter toolchain")
 	fmt.Println("\n\033[32mOptions:\033[0m")
 	fmt.Println("  \033[34mhelp\033[0m             - Show this help message")
 	fmt.Println("  \033[34mversion\033[0m          - Show version information")
 	fmt.Println("  \033[34mauthor\033[0m           - Show author information")
 }
 
-func createDirectoryStructure(projectPath string) error {
-	directories := []string{
-		projectPath,
-		filepath.Join(projectPath, "src"),
-	}
-
-	for _, dir := range directories {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func createMainMicroscript(projectPath string) error {
-	mainPath := filepath.Join(projectPath, "src", "main.microscript")
-	return os.WriteFile(mainPath, []byte(MAIN_MICROSCRIPT), 0644)
-}
-
-func createBituinConfig(projectPath, projectName string) error {
-	configPath := filepath.Join(projectPath, "bituin.toml")
-	return os.WriteFile(configPath, []byte(getBituinToml(projectName)), 0644)
-}
-
 func addMicroscriptFile(filename string) {
 	startTime := time.Now()
 
@@ -122,16 +102,148 @@ main();`
 	fmt.Printf("[%.3fs] Create file: %s\n", elapsed.Seconds(), filename)
 }
 
+func testCommand(args []string) {
+	opts := TestRunOptions{ShardIdx: 1, ShardN: 1}
+	workspace := false
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			i++
+			if i >= len(args) {
+				fmt.Println("Error: -n requires a value")
+				os.Exit(1)
+			}
+			n, err := strconv.Atoi(args[i])
+			if err != nil {
+				fmt.Printf("Error: invalid -n value %q\n", args[i])
+				os.Exit(1)
+			}
+			opts.Workers = n
+		case "-shard":
+			i++
+			if i >= len(args) {
+				fmt.Println("Error: -shard requires a value, e.g. 1/4")
+				os.Exit(1)
+			}
+			idx, n, err := parseShardFlag(args[i])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts.ShardIdx, opts.ShardN = idx, n
+		case "-v":
+			opts.Verbose = true
+		case "-k":
+			opts.KeepTemps = true
+		case "--workspace":
+			workspace = true
+		default:
+			fmt.Printf("Error: unknown test flag %q\n", args[i])
+			os.Exit(1)
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if workspace {
+		root, ok := FindWorkspaceRoot(cwd)
+		if !ok {
+			fmt.Println("Error: --workspace requires a bituin.workspace.toml above the current directory")
+			os.Exit(1)
+		}
+		ws, err := LoadWorkspace(root)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(runWorkspaceTests(ws, opts))
+	}
+
+	if _, err := os.Stat(filepath.Join(cwd, "bituin.toml")); os.IsNotExist(err) {
+		fmt.Println("Error: bituin.toml not found. Are you in a bituin project directory?")
+		os.Exit(1)
+	}
+
+	interpreter, err := findInterpreter(cwd, false)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	code, err := RunTests(cwd, interpreter, opts, os.Stdout)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+func addPackage(spec string, offline bool) {
+	startTime := time.Now()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	bituinTomlPath := filepath.Join(cwd, "bituin.toml")
+	configBytes, err := os.ReadFile(bituinTomlPath)
+	if err != nil {
+		fmt.Println("Error: Not in a Bituin project directory")
+		os.Exit(1)
+	}
+
+	name := spec
+	constraint := "*"
+	if idx := regexp.MustCompile(`@`).FindStringIndex(spec); idx != nil {
+		name = spec[:idx[0]]
+		constraint = spec[idx[1]:]
+	}
+	updatedConfig := AddDependency(string(configBytes), name, constraint)
+	if err := os.WriteFile(bituinTomlPath, []byte(updatedConfig), 0644); err != nil {
+		fmt.Printf("Error updating bituin.toml: %v\n", err)
+		os.Exit(1)
+	}
+
+	deps := ReadDependencies(updatedConfig)
+	if err := InstallDependencies(cwd, deps, offline); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if pathDeps := ReadPathDependencies(updatedConfig); len(pathDeps) > 0 {
+		if err := installPathDependencies(cwd, pathDeps); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	elapsed := time.Since(startTime)
+	fmt.Printf("[%.3fs] Added dependency: %s %s\n", elapsed.Seconds(), name, constraint)
+}
+
 func runProject(args []string) {
 	startTime := time.Now()
 	isPreview := false
-	var targetFile string
+	var targetFile, member string
 
 	// Parse arguments
 	for i := 1; i < len(args); i++ {
-		if args[i] == PREVIEW_FLAG {
+		switch args[i] {
+		case PREVIEW_FLAG:
 			isPreview = true
-		} else {
+		case "-p":
+			i++
+			if i < len(args) {
+				member = args[i]
+			}
+		default:
 			targetFile = args[i]
 		}
 	}
@@ -142,6 +254,25 @@ func runProject(args []string) {
 		os.Exit(1)
 	}
 
+	if member != "" {
+		root, ok := FindWorkspaceRoot(cwd)
+		if !ok {
+			fmt.Println("Error: -p requires a bituin.workspace.toml above the current directory")
+			os.Exit(1)
+		}
+		ws, err := LoadWorkspace(root)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		memberDir, err := ws.findMember(member)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		cwd = memberDir
+	}
+
 	bituinTomlPath := filepath.Join(cwd, "bituin.toml")
 	if _, err := os.Stat(bituinTomlPath); os.IsNotExist(err) {
 		fmt.Println("Error: bituin.toml not found. Are you in a bituin project directory?")
@@ -189,23 +320,22 @@ func runProject(args []string) {
 	}
 
 	// Look for appropriate executable
-	var executableName string
 	if isPreview {
-		executableName = "microscript-preview.exe"
 		fmt.Printf("\033[90mRunning in preview mode: %s\033[0m\n", mainFileName)
 	} else {
-		executableName = "microscript.exe"
 		fmt.Printf("\033[90mRunning: %s\033[0m\n", mainFileName)
 	}
 
-	microscriptExe := filepath.Join(cwd, "..", executableName)
-	if _, err := os.Stat(microscriptExe); os.IsNotExist(err) {
-		fmt.Printf("Error: %s not found in parent directory.\n", executableName)
+	microscriptExe, err := findInterpreter(cwd, isPreview)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Execute the MicroScript file
+	// Execute the MicroScript file, pointing the interpreter at this
+	// project's installed packages so `import` can resolve them.
 	cmd := exec.Command(microscriptExe, "run", mainFile)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("MICROSCRIPT_PATH=%s", filepath.Join(cwd, MODULES_DIR)))
 	output, err := cmd.CombinedOutput()
 
 	elapsed := time.Since(startTime)
@@ -230,7 +360,49 @@ func runProject(args []string) {
 	}
 }
 
-func createProject(projectName string, isNew bool) {
+// parseNewProjectArgs parses the --template/--author/--license/--git
+// flags that follow a project name in `bituin new`/`bituin init`.
+func parseNewProjectArgs(args []string) NewProjectOptions {
+	var opts NewProjectOptions
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--template":
+			i++
+			if i < len(args) {
+				opts.Template = args[i]
+			}
+		case "--author":
+			i++
+			if i < len(args) {
+				opts.Author = args[i]
+			}
+		case "--license":
+			i++
+			if i < len(args) {
+				opts.License = args[i]
+			}
+		case "--git":
+			opts.Git = true
+		}
+	}
+
+	return opts
+}
+
+func printTemplateList() {
+	names, err := ListTemplates()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("\033[32mAvailable templates:\033[0m")
+	for _, name := range names {
+		fmt.Printf("  \033[34m%s\033[0m\n", name)
+	}
+}
+
+func createProject(projectName string, isNew bool, opts NewProjectOptions) {
 	var projectPath string
 
 	cwd, err := os.Getwd()
@@ -252,18 +424,8 @@ func createProject(projectName string, isNew bool) {
 		}
 	}
 
-	if err := createDirectoryStructure(projectPath); err != nil {
-		fmt.Printf("Error creating directory structure: %v\n", err)
-		os.Exit(1)
-	}
-
-	if err := createMainMicroscript(projectPath); err != nil {
-		fmt.Printf("Error creating main.microscript: %v\n", err)
-		os.Exit(1)
-	}
-
-	if err := createBituinConfig(projectPath, projectName); err != nil {
-		fmt.Printf("Error creating bituin.toml: %v\n", err)
+	if err := createProjectFromTemplate(projectPath, projectName, opts); err != nil {
+		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -295,28 +457,105 @@ func main() {
 	case AUTHOR_CMD:
 		fmt.Println(AUTHOR)
 	case NEW:
+		if len(args) >= 2 && args[1] == "--list-templates" {
+			printTemplateList()
+			return
+		}
 		if len(args) < 2 {
 			fmt.Println("Error: Project name required for new command")
 			printUsage()
 			os.Exit(1)
 		}
-		createProject(args[1], true)
+		if args[1] == "file" {
+			if len(args) < 3 {
+				fmt.Println("Error: File name required for new file")
+				printUsage()
+				os.Exit(1)
+			}
+			addMicroscriptFile(args[2])
+		} else {
+			opts := parseNewProjectArgs(args[2:])
+			createProject(args[1], true, opts)
+		}
 	case INIT:
+		if len(args) >= 2 && args[1] == "--list-templates" {
+			printTemplateList()
+			return
+		}
 		if len(args) < 2 {
 			fmt.Println("Error: Project name required for init command")
 			printUsage()
 			os.Exit(1)
 		}
-		createProject(args[1], false)
+		opts := parseNewProjectArgs(args[2:])
+		createProject(args[1], false, opts)
 	case ADD:
 		if len(args) < 2 {
-			fmt.Println("Error: File name required for add command")
+			fmt.Println("Error: Package or file name required for add command")
 			printUsage()
 			os.Exit(1)
 		}
-		addMicroscriptFile(args[1])
+		if args[1] == "--file" {
+			if len(args) < 3 {
+				fmt.Println("Error: File name required for add --file")
+				printUsage()
+				os.Exit(1)
+			}
+			addMicroscriptFile(args[2])
+		} else {
+			var spec string
+			offline := false
+			for _, arg := range args[1:] {
+				if arg == "--offline" {
+					offline = true
+				} else {
+					spec = arg
+				}
+			}
+			if spec == "" {
+				fmt.Println("Error: Package name required for add command")
+				printUsage()
+				os.Exit(1)
+			}
+			addPackage(spec, offline)
+		}
 	case RUN:
 		runProject(args)
+	case TEST:
+		testCommand(args)
+	case BUILD:
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		buildCommand(cwd)
+	case FMT:
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		workspace := len(args) > 1 && args[1] == "--workspace"
+		fmtCommand(cwd, workspace)
+	case PACKAGE:
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		packageCommand(cwd)
+	case PUBLISH:
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		publishCommand(cwd)
+	case LOGIN:
+		loginCommand()
+	case TOOLCHAIN:
+		toolchainCommand(args)
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printUsage()
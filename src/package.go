@@ -0,0 +1,264 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * `bituin package` produces a deterministic, content-addressed artifact
+ * of the current project: a zip under dist/<name>-<version>.bituin plus
+ * a MANIFEST.json describing every file's SHA-256 so the archive hashes
+ * the same way on every machine that builds it.
+ */
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DIST_DIR = "dist"
+const MANIFEST_NAME = "MANIFEST.json"
+const MANIFEST_SIG_NAME = "MANIFEST.sig"
+const ARTIFACT_EXT = ".bituin"
+
+// deterministicModTime is stamped on every zip entry so that packaging
+// the same source tree twice produces byte-identical archives,
+// regardless of each file's real mtime.
+var deterministicModTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Manifest describes a packaged project: its identity, entry point,
+// declared dependencies, and the SHA-256 of every file it ships.
+type Manifest struct {
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	Main         string            `json:"main"`
+	Dependencies map[string]string `json:"dependencies"`
+	Files        map[string]string `json:"files"`
+}
+
+var packageSectionRe = regexp.MustCompile(`(?ms)^\[package\]\s*\n(.*?)(\n\[|\z)`)
+var packageNameRe = regexp.MustCompile(`(?m)^name\s*=\s*"([^"]+)"`)
+var packageVersionRe = regexp.MustCompile(`(?m)^version\s*=\s*"([^"]+)"`)
+var packageMainRe = regexp.MustCompile(`(?m)^main_file\s*=\s*"([^"]+)"`)
+
+// readPackageMeta pulls name/version/main_file out of bituin.toml's
+// [package] table only, the same way dependenciesSectionRe scopes
+// [dependencies] in dependency.go - matching against the whole file
+// would let a [dependencies] entry named e.g. "version" shadow the
+// real package version. version defaults to "0.0.0" since the
+// scaffolded bituin.toml today doesn't set one.
+func readPackageMeta(configContent string) (name, version, main string) {
+	version = "0.0.0"
+	main = "src/main.microscript"
+
+	section := packageSectionRe.FindStringSubmatch(configContent)
+	if section == nil {
+		return
+	}
+	body := section[1]
+
+	if m := packageNameRe.FindStringSubmatch(body); m != nil {
+		name = m[1]
+	}
+	if m := packageVersionRe.FindStringSubmatch(body); m != nil {
+		version = m[1]
+	}
+	if m := packageMainRe.FindStringSubmatch(body); m != nil {
+		main = m[1]
+	}
+	return
+}
+
+// collectPackageFiles walks projectRoot and returns every file to ship
+// in the artifact, excluding microscript_modules/, dist/, and version
+// control directories, sorted so archive order is deterministic.
+func collectPackageFiles(projectRoot string) ([]string, error) {
+	skip := map[string]bool{
+		MODULES_DIR: true,
+		DIST_DIR:    true,
+		".git":      true,
+	}
+
+	var files []string
+	err := filepath.Walk(projectRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(projectRoot, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		top := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+		if info.IsDir() {
+			if skip[top] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// BuildManifest hashes every file in files (as returned by
+// collectPackageFiles) and assembles the Manifest for the project at
+// projectRoot.
+func BuildManifest(projectRoot string, files []string) (*Manifest, error) {
+	configContent, err := os.ReadFile(filepath.Join(projectRoot, "bituin.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("reading bituin.toml: %w", err)
+	}
+
+	name, version, main := readPackageMeta(string(configContent))
+	if name == "" {
+		return nil, fmt.Errorf("bituin.toml has no [package] name")
+	}
+
+	manifest := &Manifest{
+		Name:         name,
+		Version:      version,
+		Main:         main,
+		Dependencies: ReadDependencies(string(configContent)),
+		Files:        map[string]string{},
+	}
+
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(projectRoot, rel))
+		if err != nil {
+			return nil, fmt.Errorf("hashing %s: %w", rel, err)
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files[filepath.ToSlash(rel)] = hex.EncodeToString(sum[:])
+	}
+
+	return manifest, nil
+}
+
+// marshalManifest serializes a Manifest with sorted, indented JSON so
+// the bytes - and therefore the signature over them - are stable.
+func marshalManifest(manifest *Manifest) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteArtifact zips files plus MANIFEST.json (and MANIFEST.sig, if
+// non-empty) from projectRoot into a deterministic archive at destPath:
+// entries are written in sorted order with a fixed mtime so the
+// resulting zip hashes identically across machines and runs.
+func WriteArtifact(projectRoot, destPath string, files []string, manifestBytes, signature []byte) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(destPath), err)
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", destPath, err)
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+
+	entries := append([]string{}, files...)
+	entries = append(entries, MANIFEST_NAME)
+	if len(signature) > 0 {
+		entries = append(entries, MANIFEST_SIG_NAME)
+	}
+	sort.Strings(entries)
+
+	for _, name := range entries {
+		var data []byte
+		switch name {
+		case MANIFEST_NAME:
+			data = manifestBytes
+		case MANIFEST_SIG_NAME:
+			data = signature
+		default:
+			data, err = os.ReadFile(filepath.Join(projectRoot, name))
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", name, err)
+			}
+		}
+
+		header := &zip.FileHeader{
+			Name:     filepath.ToSlash(name),
+			Method:   zip.Deflate,
+			Modified: deterministicModTime,
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// packageCommand implements `bituin package`: it hashes every shipped
+// file into a Manifest, optionally signs it (see signing.go), and
+// writes dist/<name>-<version>.bituin.
+func packageCommand(cwd string) {
+	files, err := collectPackageFiles(cwd)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	manifest, err := BuildManifest(cwd, files)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	manifestBytes, err := marshalManifest(manifest)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var signature []byte
+	if keyRef, ok := readSigningKeyRef(cwd); ok {
+		signature, err = signManifest(manifestBytes, keyRef)
+		if err != nil {
+			fmt.Printf("Error signing manifest: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	artifactName := fmt.Sprintf("%s-%s%s", manifest.Name, manifest.Version, ARTIFACT_EXT)
+	destPath := filepath.Join(cwd, DIST_DIR, artifactName)
+
+	if err := WriteArtifact(cwd, destPath, files, manifestBytes, signature); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Packaged %s@%s -> %s\n", manifest.Name, manifest.Version, filepath.Join(DIST_DIR, artifactName))
+}
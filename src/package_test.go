@@ -0,0 +1,49 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ */
+
+package main
+
+import "testing"
+
+func TestReadPackageMeta(t *testing.T) {
+	config := `[package]
+name = "demo"
+version = "1.2.3"
+main_file = "src/main.microscript"
+author = "someone"
+
+[dependencies]
+version = "^2.0.0"
+name = "^1.0.0"
+`
+
+	name, version, main := readPackageMeta(config)
+	if name != "demo" {
+		t.Errorf("name = %q, want %q", name, "demo")
+	}
+	if version != "1.2.3" {
+		t.Errorf("version = %q, want %q (a [dependencies] entry named \"version\" must not shadow it)", version, "1.2.3")
+	}
+	if main != "src/main.microscript" {
+		t.Errorf("main = %q, want %q", main, "src/main.microscript")
+	}
+}
+
+func TestReadPackageMetaDefaults(t *testing.T) {
+	config := `[package]
+name = "demo"
+`
+
+	name, version, main := readPackageMeta(config)
+	if name != "demo" {
+		t.Errorf("name = %q, want %q", name, "demo")
+	}
+	if version != "0.0.0" {
+		t.Errorf("version = %q, want default %q", version, "0.0.0")
+	}
+	if main != "src/main.microscript" {
+		t.Errorf("main = %q, want default %q", main, "src/main.microscript")
+	}
+}
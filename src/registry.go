@@ -0,0 +1,215 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Registry subsystem: fetches packages from a bituin registry, verifies
+ * their integrity, and unpacks them into microscript_modules/.
+ */
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_REGISTRY_URL = "https://registry.bituin.dev"
+	MODULES_DIR          = "microscript_modules"
+)
+
+// Registry talks to a bituin package registry over HTTPS.
+type Registry struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewRegistry builds a Registry pointed at baseURL, falling back to the
+// default public registry when baseURL is empty.
+func NewRegistry(baseURL string) *Registry {
+	if baseURL == "" {
+		baseURL = DEFAULT_REGISTRY_URL
+	}
+	return &Registry{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// tarballURL returns the download URL for a resolved package version.
+func (r *Registry) tarballURL(name, version string) string {
+	return fmt.Sprintf("%s/packages/%s/-/%s-%s.tgz", r.BaseURL, name, name, version)
+}
+
+// Fetch downloads the tarball for name@version and returns its raw bytes
+// along with the SHA-256 hex digest of the content.
+func (r *Registry) Fetch(name, version string) ([]byte, string, error) {
+	url := r.tarballURL(name, version)
+	resp, err := r.Client.Get(url)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching %s@%s: %w", name, version, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching %s@%s: registry returned %s", name, version, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s@%s: %w", name, version, err)
+	}
+
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChecksum reports whether data hashes to the expected SHA-256 digest.
+func VerifyChecksum(data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(got, expected) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, got)
+	}
+	return nil
+}
+
+// Unpack extracts a gzipped tarball into microscript_modules/<name>/<version>/.
+func Unpack(projectRoot, name, version string, tarball []byte) error {
+	destDir := filepath.Join(projectRoot, MODULES_DIR, name, version)
+	if err := os.RemoveAll(destDir); err != nil {
+		return fmt.Errorf("clearing %s: %w", destDir, err)
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", destDir, err)
+	}
+
+	gz, err := gzip.NewReader(strings.NewReader(string(tarball)))
+	if err != nil {
+		return fmt.Errorf("opening tarball for %s@%s: %w", name, version, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading tarball for %s@%s: %w", name, version, err)
+		}
+
+		target := filepath.Join(destDir, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("unpacking %s@%s: illegal file path %q", name, version, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			out.Close()
+		}
+	}
+
+	return nil
+}
+
+// Install resolves name@constraint against the registry, verifies its
+// checksum, unpacks it under microscript_modules/, and returns the
+// resolved LockedPackage to be written into bituin.lock.
+func (r *Registry) Install(projectRoot, name, constraint string, offline bool) (*LockedPackage, error) {
+	if offline {
+		return nil, fmt.Errorf("offline mode: cannot resolve %s%s against the registry", name, constraint)
+	}
+
+	version, err := r.resolveVersion(name, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	data, sum, err := r.Fetch(name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Unpack(projectRoot, name, version, data); err != nil {
+		return nil, err
+	}
+
+	if err := verifyUnpackedSignature(projectRoot, name, version); err != nil {
+		return nil, err
+	}
+
+	return &LockedPackage{
+		Name:    name,
+		Version: version,
+		SHA256:  sum,
+	}, nil
+}
+
+// resolveVersion asks the registry for the version list of name and picks
+// the highest version satisfying constraint.
+func (r *Registry) resolveVersion(name, constraint string) (string, error) {
+	url := fmt.Sprintf("%s/packages/%s", r.BaseURL, name)
+	resp, err := r.Client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("looking up %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("looking up %s: registry returned %s", name, resp.Status)
+	}
+
+	versions, err := parsePackageVersions(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("parsing metadata for %s: %w", name, err)
+	}
+
+	best, err := BestMatch(versions, constraint)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s%s: %w", name, constraint, err)
+	}
+	return best, nil
+}
+
+// parsePackageVersions reads a newline-separated list of semver strings
+// from the registry's package metadata response.
+func parsePackageVersions(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			versions = append(versions, line)
+		}
+	}
+	return versions, nil
+}
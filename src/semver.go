@@ -0,0 +1,129 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Minimal semver parsing and constraint matching (^, ~, exact) used by
+ * the dependency resolver to pick concrete versions from a registry's
+ * version list.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SemVer is a parsed major.minor.patch version. Pre-release/build
+// metadata is intentionally unsupported; bituin packages are expected
+// to publish plain releases.
+type SemVer struct {
+	Major, Minor, Patch int
+	Raw                 string
+}
+
+// ParseSemVer parses a "v"-prefixed or bare "major.minor.patch" string.
+func ParseSemVer(s string) (SemVer, error) {
+	raw := s
+	s = strings.TrimPrefix(s, "v")
+	parts := strings.SplitN(s, ".", 3)
+	if len(parts) != 3 {
+		return SemVer{}, fmt.Errorf("invalid semver %q", raw)
+	}
+
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("invalid semver %q: %w", raw, err)
+		}
+		nums[i] = n
+	}
+
+	return SemVer{Major: nums[0], Minor: nums[1], Patch: nums[2], Raw: raw}, nil
+}
+
+// Compare returns -1, 0, or 1 if v is less than, equal to, or greater
+// than other.
+func (v SemVer) Compare(other SemVer) int {
+	if v.Major != other.Major {
+		return sign(v.Major - other.Major)
+	}
+	if v.Minor != other.Minor {
+		return sign(v.Minor - other.Minor)
+	}
+	return sign(v.Patch - other.Patch)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Satisfies reports whether v satisfies a Cargo/npm-style constraint:
+// "^1.2.3" (compatible within the same major, or minor when major is 0),
+// "~1.2.3" (compatible within the same minor), or an exact "1.2.3".
+func (v SemVer) Satisfies(constraint string) (bool, error) {
+	constraint = strings.TrimSpace(constraint)
+	switch {
+	case constraint == "*" || constraint == "":
+		return true, nil
+	case strings.HasPrefix(constraint, "^"):
+		base, err := ParseSemVer(constraint[1:])
+		if err != nil {
+			return false, err
+		}
+		if v.Compare(base) < 0 {
+			return false, nil
+		}
+		if base.Major != 0 {
+			return v.Major == base.Major, nil
+		}
+		return v.Major == 0 && v.Minor == base.Minor, nil
+	case strings.HasPrefix(constraint, "~"):
+		base, err := ParseSemVer(constraint[1:])
+		if err != nil {
+			return false, err
+		}
+		return v.Major == base.Major && v.Minor == base.Minor && v.Compare(base) >= 0, nil
+	default:
+		base, err := ParseSemVer(constraint)
+		if err != nil {
+			return false, err
+		}
+		return v.Compare(base) == 0, nil
+	}
+}
+
+// BestMatch returns the highest version in candidates that satisfies
+// constraint, or an error if none do.
+func BestMatch(candidates []string, constraint string) (string, error) {
+	var matches []SemVer
+	for _, c := range candidates {
+		v, err := ParseSemVer(c)
+		if err != nil {
+			continue
+		}
+		ok, err := v.Satisfies(constraint)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			matches = append(matches, v)
+		}
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no version satisfies %q", constraint)
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Compare(matches[j]) > 0 })
+	return matches[0].Raw, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ */
+
+package main
+
+import "testing"
+
+func TestSatisfies(t *testing.T) {
+	cases := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"1.2.3", "^1.2.0", true},
+		{"1.9.9", "^1.2.0", true},
+		{"2.0.0", "^1.2.0", false},
+		{"1.1.9", "^1.2.0", false},
+		{"0.2.3", "^0.2.0", true},
+		{"0.3.0", "^0.2.0", false},
+		{"1.2.5", "~1.2.0", true},
+		{"1.3.0", "~1.2.0", false},
+		{"1.2.0", "1.2.0", true},
+		{"1.2.1", "1.2.0", false},
+		{"9.9.9", "*", true},
+		{"9.9.9", "", true},
+	}
+
+	for _, c := range cases {
+		v, err := ParseSemVer(c.version)
+		if err != nil {
+			t.Fatalf("ParseSemVer(%q): %v", c.version, err)
+		}
+		got, err := v.Satisfies(c.constraint)
+		if err != nil {
+			t.Fatalf("Satisfies(%q, %q): %v", c.version, c.constraint, err)
+		}
+		if got != c.want {
+			t.Errorf("%s satisfies %q = %v, want %v", c.version, c.constraint, got, c.want)
+		}
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	candidates := []string{"1.0.0", "1.2.0", "1.2.5", "1.9.0", "2.0.0"}
+
+	best, err := BestMatch(candidates, "^1.2.0")
+	if err != nil {
+		t.Fatalf("BestMatch: %v", err)
+	}
+	if best != "1.9.0" {
+		t.Errorf("BestMatch(^1.2.0) = %q, want 1.9.0", best)
+	}
+
+	best, err = BestMatch(candidates, "~1.2.0")
+	if err != nil {
+		t.Fatalf("BestMatch: %v", err)
+	}
+	if best != "1.2.5" {
+		t.Errorf("BestMatch(~1.2.0) = %q, want 1.2.5", best)
+	}
+
+	if _, err := BestMatch(candidates, "^3.0.0"); err == nil {
+		t.Error("expected error for unsatisfiable constraint, got nil")
+	}
+}
@@ -0,0 +1,311 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Manifest signing and publishing. `bituin publish` ships a packaged
+ * artifact to a registry using a bearer token from
+ * ~/.bituin/credentials.toml, optionally signing MANIFEST.json with an
+ * RSA or Ed25519 key so consumers can verify provenance against a
+ * trusted keyring before installing.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const CREDENTIALS_FILE = "credentials.toml"
+
+var signingKeyRe = regexp.MustCompile(`(?m)^signing_key\s*=\s*"([^"]+)"`)
+
+// readSigningKeyRef returns the path in bituin.toml's
+// [package].signing_key, if set.
+func readSigningKeyRef(projectRoot string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, "bituin.toml"))
+	if err != nil {
+		return "", false
+	}
+	section := packageSectionRe.FindStringSubmatch(string(data))
+	if section == nil {
+		return "", false
+	}
+	m := signingKeyRe.FindStringSubmatch(section[1])
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// signManifest signs data with the PEM-encoded RSA or Ed25519 private
+// key at keyPath, returning the raw signature bytes.
+func signManifest(data []byte, keyPath string) ([]byte, error) {
+	pemBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key %s: %w", keyPath, err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", keyPath)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key %s: %w", keyPath, err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, data), nil
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// VerifyManifestSignature reports whether signature is valid for data
+// under any public key found in trustedKeysDir (each a PEM-encoded
+// PKIX public key). It returns an error only when the keyring itself
+// can't be read; an unmatched signature is reported via the bool.
+func VerifyManifestSignature(data, signature []byte, trustedKeysDir string) (bool, error) {
+	entries, err := os.ReadDir(trustedKeysDir)
+	if err != nil {
+		return false, fmt.Errorf("reading trusted keyring %s: %w", trustedKeysDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		pemBytes, err := os.ReadFile(filepath.Join(trustedKeysDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		block, _ := pem.Decode(pemBytes)
+		if block == nil {
+			continue
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			continue
+		}
+
+		switch k := pub.(type) {
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, data, signature) {
+				return true, nil
+			}
+		case *rsa.PublicKey:
+			digest := sha256.Sum256(data)
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// trustedKeysDir returns ~/.bituin/trusted_keys/, the keyring consumers
+// verify published packages against.
+func trustedKeysDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".bituin", "trusted_keys")
+}
+
+func credentialsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".bituin", CREDENTIALS_FILE)
+}
+
+var credentialsTokenRe = regexp.MustCompile(`(?m)^token\s*=\s*"([^"]+)"`)
+
+// readCredentialsToken reads the bearer token written by `bituin login`.
+func readCredentialsToken() (string, error) {
+	data, err := os.ReadFile(credentialsPath())
+	if err != nil {
+		return "", fmt.Errorf("not logged in; run `bituin login` first: %w", err)
+	}
+	m := credentialsTokenRe.FindStringSubmatch(string(data))
+	if m == nil {
+		return "", fmt.Errorf("%s has no token; run `bituin login` again", CREDENTIALS_FILE)
+	}
+	return m[1], nil
+}
+
+// loginCommand reads a registry token from stdin and stores it in
+// ~/.bituin/credentials.toml for `bituin publish` to use.
+func loginCommand() {
+	fmt.Print("Registry token: ")
+	reader := bufio.NewReader(os.Stdin)
+	token, err := reader.ReadString('\n')
+	if err != nil {
+		fmt.Printf("Error reading token: %v\n", err)
+		os.Exit(1)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		fmt.Println("Error: empty token")
+		os.Exit(1)
+	}
+
+	path := credentialsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	content := fmt.Sprintf("[credentials]\ntoken = %q\n", token)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		fmt.Printf("Error writing %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Logged in.")
+}
+
+// verifyUnpackedSignature checks a just-unpacked package directory for a
+// MANIFEST.json/MANIFEST.sig pair and verifies it against
+// ~/.bituin/trusted_keys/ when that keyring exists and is non-empty;
+// otherwise nothing is checked. A package without MANIFEST.json is
+// accepted without a check, and one without MANIFEST.sig is accepted
+// with a warning; only a signature that matches no trusted key is an
+// error.
+func verifyUnpackedSignature(projectRoot, name, version string) error {
+	dir := filepath.Join(projectRoot, MODULES_DIR, name, version)
+	manifestPath := filepath.Join(dir, MANIFEST_NAME)
+	sigPath := filepath.Join(dir, MANIFEST_SIG_NAME)
+
+	keys, err := os.ReadDir(trustedKeysDir())
+	if err != nil || len(keys) == 0 {
+		return nil
+	}
+
+	manifestBytes, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil
+	}
+	signature, err := os.ReadFile(sigPath)
+	if err != nil {
+		fmt.Printf("Warning: %s@%s has no MANIFEST.sig; trusted keyring is configured but the package is unsigned\n", name, version)
+		return nil
+	}
+
+	ok, err := VerifyManifestSignature(manifestBytes, signature, trustedKeysDir())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s@%s: MANIFEST.sig does not match any key in %s", name, version, trustedKeysDir())
+	}
+	return nil
+}
+
+// publishCommand packages the current project (reusing the same
+// manifest/signing/archive logic as `bituin package`) and POSTs it to
+// the registry as a multipart upload authenticated with the bearer
+// token from `bituin login`.
+func publishCommand(cwd string) {
+	token, err := readCredentialsToken()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	files, err := collectPackageFiles(cwd)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	manifest, err := BuildManifest(cwd, files)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	manifestBytes, err := marshalManifest(manifest)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var signature []byte
+	if keyRef, ok := readSigningKeyRef(cwd); ok {
+		signature, err = signManifest(manifestBytes, keyRef)
+		if err != nil {
+			fmt.Printf("Error signing manifest: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	artifactName := fmt.Sprintf("%s-%s%s", manifest.Name, manifest.Version, ARTIFACT_EXT)
+	destPath := filepath.Join(cwd, DIST_DIR, artifactName)
+	if err := WriteArtifact(cwd, destPath, files, manifestBytes, signature); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	registry := NewRegistry(os.Getenv(REGISTRY_URL_ENV))
+	url := fmt.Sprintf("%s/packages/%s/%s", registry.BaseURL, manifest.Name, manifest.Version)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	artifactData, err := os.ReadFile(destPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if part, err := mw.CreateFormFile("artifact", artifactName); err == nil {
+		part.Write(artifactData)
+	}
+	if part, err := mw.CreateFormFile("manifest", MANIFEST_NAME); err == nil {
+		part.Write(manifestBytes)
+	}
+	mw.Close()
+
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := registry.Client.Do(req)
+	if err != nil {
+		fmt.Printf("Error publishing %s@%s: %v\n", manifest.Name, manifest.Version, err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		fmt.Printf("Error: registry returned %s\n", resp.Status)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Published %s@%s\n", manifest.Name, manifest.Version)
+}
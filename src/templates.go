@@ -0,0 +1,264 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Project templates. `bituin new` used to write a single hardcoded
+ * MAIN_MICROSCRIPT string; this renders one of several embedded
+ * archetypes (bin, lib, plugin, web, game) through text/template, with
+ * room for templates contributed locally or fetched from a git remote.
+ */
+
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/*
+var embeddedTemplates embed.FS
+
+const embeddedTemplatesRoot = "templates"
+const DEFAULT_TEMPLATE = "bin"
+
+// TemplateVars are the placeholders available to every template file.
+type TemplateVars struct {
+	ProjectName string
+	Author      string
+	Year        string
+	License     string
+}
+
+// NewProjectOptions configures a `bituin new` / `bituin init` invocation.
+type NewProjectOptions struct {
+	Template string
+	Author   string
+	License  string
+	Git      bool
+}
+
+// ListTemplates returns the names of every built-in and user template,
+// i.e. the embedded archetypes plus anything under
+// ~/.bituin/templates/<name>/.
+func ListTemplates() ([]string, error) {
+	names := map[string]bool{}
+
+	entries, err := fs.ReadDir(embeddedTemplates, embeddedTemplatesRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			names[e.Name()] = true
+		}
+	}
+
+	if userDir := userTemplatesDir(); userDir != "" {
+		if entries, err := os.ReadDir(userDir); err == nil {
+			for _, e := range entries {
+				if e.IsDir() {
+					names[e.Name()] = true
+				}
+			}
+		}
+	}
+
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+func userTemplatesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".bituin", "templates")
+}
+
+func templateCacheDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".bituin", "template-cache")
+}
+
+// resolveTemplateFS returns the filesystem root to render a template
+// from: the embedded archetypes, a user template under
+// ~/.bituin/templates/, or a shallow git clone of a "gh:user/repo"
+// remote reference.
+func resolveTemplateFS(name string) (fs.FS, string, error) {
+	if strings.HasPrefix(name, "gh:") {
+		dir, err := fetchRemoteTemplate(name)
+		if err != nil {
+			return nil, "", err
+		}
+		return os.DirFS(dir), dir, nil
+	}
+
+	if userDir := userTemplatesDir(); userDir != "" {
+		candidate := filepath.Join(userDir, name)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return os.DirFS(candidate), candidate, nil
+		}
+	}
+
+	root := filepath.Join(embeddedTemplatesRoot, name)
+	if _, err := fs.Stat(embeddedTemplates, root); err != nil {
+		return nil, "", fmt.Errorf("unknown template %q (run `bituin new --list-templates`)", name)
+	}
+	sub, err := fs.Sub(embeddedTemplates, root)
+	if err != nil {
+		return nil, "", err
+	}
+	return sub, root, nil
+}
+
+// githubSlugRe restricts "gh:<slug>" references to a plain user/repo
+// shape so the slug can't be used to escape the template cache
+// directory (e.g. via "../../" segments) when it's joined into a
+// filesystem path and a clone URL.
+var githubSlugRe = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// fetchRemoteTemplate shallow-clones "gh:user/repo" into
+// ~/.bituin/template-cache/user/repo and returns that path.
+func fetchRemoteTemplate(ref string) (string, error) {
+	slug := strings.TrimPrefix(ref, "gh:")
+	if !githubSlugRe.MatchString(slug) {
+		return "", fmt.Errorf("invalid template reference %q: expected gh:user/repo", ref)
+	}
+
+	dest := filepath.Join(templateCacheDir(), slug)
+
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		return dest, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", fmt.Errorf("preparing template cache: %w", err)
+	}
+
+	url := fmt.Sprintf("https://github.com/%s.git", slug)
+	cmd := exec.Command("git", "clone", "--depth", "1", url, dest)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("cloning template %s: %v\n%s", ref, err, output)
+	}
+
+	return dest, nil
+}
+
+// renderTemplateTree walks templateFS and writes every file into
+// destDir, rendering files whose name ends in ".tmpl" through
+// text/template and dropping the suffix.
+func renderTemplateTree(templateFS fs.FS, destDir string, vars TemplateVars) error {
+	return fs.WalkDir(templateFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == "." {
+			return nil
+		}
+
+		target := filepath.Join(destDir, strings.TrimSuffix(path, ".tmpl"))
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := fs.ReadFile(templateFS, path)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		if !strings.HasSuffix(path, ".tmpl") {
+			return os.WriteFile(target, data, 0644)
+		}
+
+		tmpl, err := template.New(path).Parse(string(data))
+		if err != nil {
+			return fmt.Errorf("parsing template %s: %w", path, err)
+		}
+
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		return tmpl.Execute(out, vars)
+	})
+}
+
+const DEFAULT_GITIGNORE = `microscript_modules/
+dist/
+*.log
+`
+
+// createProjectFromTemplate scaffolds projectPath from the named
+// template, optionally running `git init` and writing a .gitignore.
+func createProjectFromTemplate(projectPath, projectName string, opts NewProjectOptions) error {
+	templateName := opts.Template
+	if templateName == "" {
+		templateName = DEFAULT_TEMPLATE
+	}
+
+	templateFS, _, err := resolveTemplateFS(templateName)
+	if err != nil {
+		return err
+	}
+
+	vars := TemplateVars{
+		ProjectName: projectName,
+		Author:      opts.Author,
+		Year:        strconv.Itoa(time.Now().Year()),
+		License:     opts.License,
+	}
+	if vars.Author == "" {
+		vars.Author = AUTHOR
+	}
+	if vars.License == "" {
+		vars.License = "MIT"
+	}
+
+	if err := os.MkdirAll(projectPath, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", projectPath, err)
+	}
+
+	if err := renderTemplateTree(templateFS, projectPath, vars); err != nil {
+		return fmt.Errorf("rendering template %q: %w", templateName, err)
+	}
+
+	if opts.Git {
+		cmd := exec.Command("git", "init")
+		cmd.Dir = projectPath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("running git init: %v\n%s", err, output)
+		}
+		gitignorePath := filepath.Join(projectPath, ".gitignore")
+		if _, err := os.Stat(gitignorePath); os.IsNotExist(err) {
+			if err := os.WriteFile(gitignorePath, []byte(DEFAULT_GITIGNORE), 0644); err != nil {
+				return fmt.Errorf("writing .gitignore: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
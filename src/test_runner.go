@@ -0,0 +1,363 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * `bituin test` discovers *.test.microscript files under tests/ and runs
+ * each against the interpreter concurrently. The dispatch loop mirrors a
+ * worker pool reading test descriptors off a channel, with the main
+ * goroutine aggregating results - the same shape Go's own test harness
+ * uses to fan work out across CPUs.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TEST_DIR             = "tests"
+	TEST_FILE_SUFFIX     = ".test.microscript"
+	DEFAULT_TEST_TIMEOUT = 60 * time.Second
+)
+
+// TestMode is the declared behavior of a test file.
+type TestMode string
+
+const (
+	ModeRun        TestMode = "run"
+	ModeErrorCheck TestMode = "errorcheck"
+	ModeRunOutput  TestMode = "runoutput"
+	ModeSkip       TestMode = "skip"
+)
+
+// TestDescriptor is everything the runner needs to execute one test
+// file, parsed from its `// bituin:` header.
+type TestDescriptor struct {
+	Path    string
+	Mode    TestMode
+	Want    *regexp.Regexp
+	Timeout time.Duration
+	Tags    []string
+}
+
+// TestResult is what a worker reports back for one TestDescriptor.
+type TestResult struct {
+	Descriptor TestDescriptor
+	Passed     bool
+	Skipped    bool
+	Stdout     string
+	Stderr     string
+	Elapsed    time.Duration
+	Err        error
+}
+
+var (
+	headerDirectiveRe   = regexp.MustCompile(`^//\s*bituin:\s*(\S+)`)
+	wantDirectiveRe     = regexp.MustCompile(`^//\s*want:\s*(.+)$`)
+	timeoutDirectiveRe  = regexp.MustCompile(`^//\s*timeout:\s*(\S+)$`)
+	buildTagDirectiveRe = regexp.MustCompile(`^//\s*\+build\s+(.+)$`)
+)
+
+// DiscoverTests walks root/tests looking for *.test.microscript files and
+// parses each file's header directives into a TestDescriptor.
+func DiscoverTests(root string) ([]TestDescriptor, error) {
+	dir := filepath.Join(root, TEST_DIR)
+	var descriptors []TestDescriptor
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, TEST_FILE_SUFFIX) {
+			return nil
+		}
+
+		desc, err := parseTestHeader(path)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		descriptors = append(descriptors, desc)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	sort.Slice(descriptors, func(i, j int) bool { return descriptors[i].Path < descriptors[j].Path })
+	return descriptors, nil
+}
+
+// parseTestHeader reads the leading comment block of a test file and
+// extracts its `// bituin:`, `// want:`, `// timeout:` and `// +build`
+// directives.
+func parseTestHeader(path string) (TestDescriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return TestDescriptor{}, err
+	}
+
+	desc := TestDescriptor{
+		Path:    path,
+		Mode:    ModeRun,
+		Timeout: DEFAULT_TEST_TIMEOUT,
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+
+		if m := headerDirectiveRe.FindStringSubmatch(line); m != nil {
+			desc.Mode = TestMode(m[1])
+			continue
+		}
+		if m := wantDirectiveRe.FindStringSubmatch(line); m != nil {
+			re, err := regexp.Compile(m[1])
+			if err != nil {
+				return TestDescriptor{}, fmt.Errorf("invalid want regex: %w", err)
+			}
+			desc.Want = re
+			continue
+		}
+		if m := timeoutDirectiveRe.FindStringSubmatch(line); m != nil {
+			d, err := time.ParseDuration(m[1])
+			if err != nil {
+				return TestDescriptor{}, fmt.Errorf("invalid timeout: %w", err)
+			}
+			desc.Timeout = d
+			continue
+		}
+		if m := buildTagDirectiveRe.FindStringSubmatch(line); m != nil {
+			desc.Tags = append(desc.Tags, strings.Fields(m[1])...)
+			continue
+		}
+	}
+
+	return desc, nil
+}
+
+// TestRunOptions configures a `bituin test` invocation.
+type TestRunOptions struct {
+	Workers   int
+	ShardIdx  int
+	ShardN    int
+	Verbose   bool
+	KeepTemps bool
+}
+
+// runTest executes one test file against the given interpreter
+// executable in its own temp working directory, honoring the
+// descriptor's timeout and comparing stdout against `// want:` when
+// present.
+func runTest(interpreter string, desc TestDescriptor, keepTemps bool) TestResult {
+	start := time.Now()
+
+	if desc.Mode == ModeSkip {
+		return TestResult{Descriptor: desc, Skipped: true, Elapsed: time.Since(start)}
+	}
+
+	workDir, err := os.MkdirTemp("", "bituin-test-*")
+	if err != nil {
+		return TestResult{Descriptor: desc, Err: fmt.Errorf("creating temp dir: %w", err), Elapsed: time.Since(start)}
+	}
+	if !keepTemps {
+		defer os.RemoveAll(workDir)
+	}
+
+	cmd := exec.Command(interpreter, "run", desc.Path)
+	cmd.Dir = workDir
+
+	done := make(chan error, 1)
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return TestResult{Descriptor: desc, Err: fmt.Errorf("starting interpreter: %w", err), Elapsed: time.Since(start)}
+	}
+	go func() { done <- cmd.Wait() }()
+
+	var runErr error
+	select {
+	case runErr = <-done:
+	case <-time.After(desc.Timeout):
+		cmd.Process.Kill()
+		<-done
+		runErr = fmt.Errorf("timed out after %s", desc.Timeout)
+	}
+
+	result := TestResult{
+		Descriptor: desc,
+		Stdout:     stdout.String(),
+		Stderr:     stderr.String(),
+		Elapsed:    time.Since(start),
+	}
+
+	switch desc.Mode {
+	case ModeErrorCheck:
+		result.Passed = runErr != nil
+	case ModeRunOutput:
+		// runoutput exists to check a test's produced output, so unlike
+		// plain run it requires a // want: directive to compare against
+		// rather than treating a zero exit code alone as a pass.
+		if desc.Want == nil {
+			result.Passed = false
+			result.Err = fmt.Errorf("runoutput mode requires a // want: directive")
+			return result
+		}
+		result.Passed = runErr == nil
+	default:
+		result.Passed = runErr == nil
+	}
+
+	if result.Passed && desc.Want != nil {
+		result.Passed = desc.Want.MatchString(result.Stdout)
+	}
+	if !result.Passed && result.Err == nil && runErr != nil && desc.Mode != ModeErrorCheck {
+		result.Err = runErr
+	}
+
+	return result
+}
+
+// RunTests discovers and executes test files matching the requested
+// shard, fanning them out across a worker pool and writing a colored
+// pass/fail/skip summary to w. Callers that run several of these
+// concurrently (e.g. one per workspace member) should give each its own
+// buffer and flush it as a block, since results are written as they
+// complete and would otherwise interleave across goroutines.
+func RunTests(projectRoot, interpreter string, opts TestRunOptions, w io.Writer) (int, error) {
+	all, err := DiscoverTests(projectRoot)
+	if err != nil {
+		return 1, err
+	}
+
+	shard := shardTests(all, opts.ShardIdx, opts.ShardN)
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(shard) && len(shard) > 0 {
+		workers = len(shard)
+	}
+
+	jobs := make(chan TestDescriptor)
+	results := make(chan TestResult)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for desc := range jobs {
+				results <- runTest(interpreter, desc, opts.KeepTemps)
+			}
+		}()
+	}
+
+	go func() {
+		for _, desc := range shard {
+			jobs <- desc
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	start := time.Now()
+	var passed, failed, skipped int
+	for result := range results {
+		if opts.Verbose {
+			fmt.Fprintf(w, "  \033[90m%-10s %s (%.3fs)\033[0m\n", string(result.Descriptor.Mode), result.Descriptor.Path, result.Elapsed.Seconds())
+		}
+
+		switch {
+		case result.Skipped:
+			skipped++
+			fmt.Fprintf(w, "\033[90mSKIP\033[0m %s\n", result.Descriptor.Path)
+		case result.Passed:
+			passed++
+			fmt.Fprintf(w, "\033[32mPASS\033[0m %s (%.3fs)\n", result.Descriptor.Path, result.Elapsed.Seconds())
+		default:
+			failed++
+			fmt.Fprintf(w, "\033[31mFAIL\033[0m %s (%.3fs)\n", result.Descriptor.Path, result.Elapsed.Seconds())
+			if result.Err != nil {
+				fmt.Fprintf(w, "  error: %v\n", result.Err)
+			}
+			if result.Descriptor.Want != nil {
+				fmt.Fprintf(w, "  want:  /%s/\n", result.Descriptor.Want.String())
+				fmt.Fprintf(w, "  got:   %s\n", strings.TrimRight(result.Stdout, "\n"))
+			}
+			if result.Stderr != "" {
+				fmt.Fprintf(w, "  stderr: %s\n", strings.TrimRight(result.Stderr, "\n"))
+			}
+		}
+	}
+
+	elapsed := time.Since(start)
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped in %.3fs\n", passed, failed, skipped, elapsed.Seconds())
+
+	if failed > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// shardTests restricts all to the 1-indexed idx/n shard, used for
+// splitting a suite across CI machines.
+func shardTests(all []TestDescriptor, idx, n int) []TestDescriptor {
+	if n <= 1 {
+		return all
+	}
+	var shard []TestDescriptor
+	for i, desc := range all {
+		if i%n == idx-1 {
+			shard = append(shard, desc)
+		}
+	}
+	return shard
+}
+
+// parseShardFlag parses a "-shard N/M" value into its 1-indexed
+// components.
+func parseShardFlag(value string) (int, int, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid -shard value %q, expected N/M", value)
+	}
+	idx, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -shard value %q: %w", value, err)
+	}
+	n, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -shard value %q: %w", value, err)
+	}
+	if idx < 1 || idx > n {
+		return 0, 0, fmt.Errorf("invalid -shard value %q: N must be in [1, M]", value)
+	}
+	return idx, n, nil
+}
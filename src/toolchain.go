@@ -0,0 +1,225 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Interpreter discovery. Earlier versions assumed a Windows-only layout
+ * where microscript.exe lived in the parent directory; this replaces
+ * that with a search that also works on Linux and macOS and outside a
+ * single fixed directory layout.
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	MICROSCRIPT_HOME_ENV = "MICROSCRIPT_HOME"
+	TOOLCHAIN_MARKER     = ".bituin/toolchain"
+	TOOLCHAIN_URL_ENV    = "BITUIN_TOOLCHAIN_URL"
+)
+
+// interpreterBinaryName returns the platform-appropriate executable
+// name for the MicroScript interpreter.
+func interpreterBinaryName(preview bool) string {
+	name := "microscript"
+	if preview {
+		name = "microscript-preview"
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+var toolchainVersionRe = regexp.MustCompile(`(?m)^\[toolchain\]\s*\nversion\s*=\s*"([^"]+)"`)
+
+// findInterpreter locates the MicroScript interpreter for the project
+// rooted at projectRoot, trying in order:
+//
+//  1. $MICROSCRIPT_HOME/<binary>
+//  2. <binary> on $PATH
+//  3. walking parent directories for a .bituin/toolchain file naming
+//     an interpreter path
+//  4. a [toolchain] version pinned in bituin.toml, resolved from the
+//     cached toolchain under ~/.bituin/toolchains/<version>/
+//
+// It returns an error naming every location checked when none match.
+func findInterpreter(projectRoot string, preview bool) (string, error) {
+	binary := interpreterBinaryName(preview)
+	var tried []string
+
+	if home := os.Getenv(MICROSCRIPT_HOME_ENV); home != "" {
+		candidate := filepath.Join(home, binary)
+		tried = append(tried, candidate)
+		if fileExists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	if path, err := exec.LookPath(binary); err == nil {
+		return path, nil
+	}
+	tried = append(tried, binary+" (in $PATH)")
+
+	if marker, err := findToolchainMarker(projectRoot); err == nil {
+		candidate := strings.TrimSpace(marker)
+		tried = append(tried, candidate)
+		if fileExists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	if version, ok := readToolchainVersion(projectRoot); ok {
+		candidate := filepath.Join(toolchainsDir(), version, binary)
+		tried = append(tried, candidate)
+		if fileExists(candidate) {
+			return candidate, nil
+		}
+		return "", fmt.Errorf("toolchain %s not installed; run `bituin toolchain install %s` (tried: %s)", version, version, strings.Join(tried, ", "))
+	}
+
+	// Fall back to the historical single-directory layout so existing
+	// projects keep working unmodified.
+	legacy := filepath.Join(projectRoot, "..", binary)
+	tried = append(tried, legacy)
+	if fileExists(legacy) {
+		return legacy, nil
+	}
+
+	return "", fmt.Errorf("could not find %s (tried: %s)", binary, strings.Join(tried, ", "))
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// findToolchainMarker walks projectRoot and its ancestors looking for a
+// .bituin/toolchain file, returning its contents (the interpreter path)
+// when found.
+func findToolchainMarker(projectRoot string) (string, error) {
+	dir := projectRoot
+	for {
+		markerPath := filepath.Join(dir, TOOLCHAIN_MARKER)
+		if data, err := os.ReadFile(markerPath); err == nil {
+			return string(data), nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s found above %s", TOOLCHAIN_MARKER, projectRoot)
+		}
+		dir = parent
+	}
+}
+
+// readToolchainVersion reads the pinned interpreter version from the
+// project's [toolchain] table in bituin.toml, if present.
+func readToolchainVersion(projectRoot string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, "bituin.toml"))
+	if err != nil {
+		return "", false
+	}
+	m := toolchainVersionRe.FindStringSubmatch(string(data))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// toolchainsDir is where downloaded interpreter toolchains are cached.
+func toolchainsDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".bituin", "toolchains")
+}
+
+// toolchainCommand implements `bituin toolchain install <version>
+// [--sha256 <hex>]`, the command findInterpreter's error message points
+// users at when no interpreter can be found.
+func toolchainCommand(args []string) {
+	if len(args) < 2 || args[1] != "install" || len(args) < 3 {
+		fmt.Println("Usage: bituin toolchain install <version> [--sha256 <hex>]")
+		os.Exit(1)
+	}
+
+	version := args[2]
+	var expectedSHA256 string
+	for i := 3; i < len(args); i++ {
+		if args[i] == "--sha256" && i+1 < len(args) {
+			expectedSHA256 = args[i+1]
+			i++
+		}
+	}
+
+	path, err := InstallToolchain(version, expectedSHA256)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Installed toolchain %s -> %s\n", version, path)
+}
+
+// InstallToolchain downloads the interpreter for version from
+// BITUIN_TOOLCHAIN_URL (or the default toolchain registry), verifies its
+// SHA-256 checksum against expectedSHA256, and caches it under
+// ~/.bituin/toolchains/<version>/. When expectedSHA256 is empty the
+// download is cached without a checksum check.
+func InstallToolchain(version, expectedSHA256 string) (string, error) {
+	baseURL := os.Getenv(TOOLCHAIN_URL_ENV)
+	if baseURL == "" {
+		baseURL = "https://toolchains.bituin.dev"
+	}
+
+	binary := interpreterBinaryName(false)
+	url := fmt.Sprintf("%s/%s/%s-%s", strings.TrimRight(baseURL, "/"), version, runtime.GOOS, runtime.GOARCH)
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("downloading toolchain %s: %w", version, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading toolchain %s: server returned %s", version, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("downloading toolchain %s: %w", version, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); expectedSHA256 != "" && got != expectedSHA256 {
+		return "", fmt.Errorf("toolchain %s checksum mismatch: expected %s, got %s", version, expectedSHA256, got)
+	}
+
+	destDir := filepath.Join(toolchainsDir(), version)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", destDir, err)
+	}
+
+	destPath := filepath.Join(destDir, binary)
+	if err := os.WriteFile(destPath, data, 0755); err != nil {
+		return "", fmt.Errorf("writing %s: %w", destPath, err)
+	}
+
+	return destPath, nil
+}
@@ -0,0 +1,331 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ *
+ * Workspace mode: a bituin.workspace.toml at a repo root lists member
+ * packages so `run`, `add`, `test`, and `build` can operate across all
+ * of them without each member repeating registry/lockfile setup.
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const WORKSPACE_TOML = "bituin.workspace.toml"
+
+// Workspace is a parsed bituin.workspace.toml: the directory it lives in
+// plus the package directories it resolves to.
+type Workspace struct {
+	Root    string
+	Members []string
+}
+
+var workspaceMembersRe = regexp.MustCompile(`(?ms)^members\s*=\s*\[(.*?)\]`)
+var workspaceMemberEntryRe = regexp.MustCompile(`"([^"]+)"`)
+
+// FindWorkspaceRoot walks startDir and its ancestors looking for
+// bituin.workspace.toml, the same upward search findToolchainMarker uses
+// for the .bituin/toolchain marker.
+func FindWorkspaceRoot(startDir string) (string, bool) {
+	dir := startDir
+	for {
+		if _, err := os.Stat(filepath.Join(dir, WORKSPACE_TOML)); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// LoadWorkspace reads bituin.workspace.toml at root and expands its
+// `members` glob patterns (e.g. "pkg-b/*") into concrete package
+// directories, each one expected to contain its own bituin.toml.
+func LoadWorkspace(root string) (*Workspace, error) {
+	data, err := os.ReadFile(filepath.Join(root, WORKSPACE_TOML))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", WORKSPACE_TOML, err)
+	}
+
+	m := workspaceMembersRe.FindStringSubmatch(string(data))
+	if m == nil {
+		return nil, fmt.Errorf("%s has no members = [...] table", WORKSPACE_TOML)
+	}
+
+	var patterns []string
+	for _, entry := range workspaceMemberEntryRe.FindAllStringSubmatch(m[1], -1) {
+		patterns = append(patterns, entry[1])
+	}
+
+	memberSet := map[string]bool{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("expanding member pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && info.IsDir() {
+				if _, err := os.Stat(filepath.Join(match, "bituin.toml")); err == nil {
+					memberSet[match] = true
+				}
+			}
+		}
+	}
+
+	var members []string
+	for dir := range memberSet {
+		members = append(members, dir)
+	}
+	sort.Strings(members)
+
+	return &Workspace{Root: root, Members: members}, nil
+}
+
+// memberName returns a member directory's display name relative to the
+// workspace root.
+func (w *Workspace) memberName(memberDir string) string {
+	rel, err := filepath.Rel(w.Root, memberDir)
+	if err != nil {
+		return memberDir
+	}
+	return rel
+}
+
+// findMember resolves a `-p <member>` argument (matched against the
+// member's base directory name or its path relative to the workspace
+// root) to its absolute directory.
+func (w *Workspace) findMember(name string) (string, error) {
+	for _, dir := range w.Members {
+		if w.memberName(dir) == name || filepath.Base(dir) == name {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no workspace member named %q", name)
+}
+
+// runWorkspaceTests runs `bituin test` in every workspace member
+// concurrently, reporting each member's pass/fail status and elapsed
+// time as it finishes. Each member's RunTests output is buffered and
+// flushed as one block under mu so concurrent members can't interleave
+// their PASS/FAIL/summary lines on stdout.
+func runWorkspaceTests(ws *Workspace, opts TestRunOptions) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	exitCode := 0
+
+	for _, memberDir := range ws.Members {
+		memberDir := memberDir
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := ws.memberName(memberDir)
+
+			interpreter, err := findInterpreter(memberDir, false)
+			if err != nil {
+				mu.Lock()
+				fmt.Printf("\033[31m[%s] Error: %v\033[0m\n", name, err)
+				exitCode = 1
+				mu.Unlock()
+				return
+			}
+
+			var buf bytes.Buffer
+			code, err := RunTests(memberDir, interpreter, opts, &buf)
+
+			mu.Lock()
+			fmt.Printf("\033[90m[%s]\033[0m running tests...\n", name)
+			fmt.Print(buf.String())
+			if err != nil {
+				fmt.Printf("\033[31m[%s] Error: %v\033[0m\n", name, err)
+				exitCode = 1
+			} else if code != 0 {
+				exitCode = code
+			}
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return exitCode
+}
+
+// buildMember performs a minimal `bituin build`: it resolves the
+// member's main file and confirms it exists. MicroScript has no
+// separate compile step today, so this is the validation a real build
+// would do before handing off to a future bytecode/AOT backend.
+func buildMember(memberDir string) error {
+	configPath := filepath.Join(memberDir, "bituin.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("reading bituin.toml: %w", err)
+	}
+
+	re := regexp.MustCompile(`main_file\s*=\s*"([^"]+)"`)
+	matches := re.FindStringSubmatch(string(data))
+	mainFile := filepath.Join(memberDir, "src", "main.microscript")
+	if matches != nil {
+		mainFile = filepath.Join(memberDir, matches[1])
+	}
+
+	if _, err := os.Stat(mainFile); err != nil {
+		return fmt.Errorf("main file %q not found", mainFile)
+	}
+	return nil
+}
+
+// buildCommand implements `bituin build`, building every workspace
+// member when run from inside a workspace, or just the current project
+// otherwise.
+func buildCommand(cwd string) {
+	if root, ok := FindWorkspaceRoot(cwd); ok {
+		ws, err := LoadWorkspace(root)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		failed := false
+		for _, memberDir := range ws.Members {
+			name := ws.memberName(memberDir)
+			if err := buildMember(memberDir); err != nil {
+				fmt.Printf("\033[31m[%s] Error: %v\033[0m\n", name, err)
+				failed = true
+				continue
+			}
+			fmt.Printf("\033[32m[%s] OK\033[0m\n", name)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := buildMember(cwd); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("\033[32mOK\033[0m")
+}
+
+// fmtCommand is the hook point for `bituin fmt`; a real MicroScript
+// formatter doesn't exist yet, so this only resolves the scope
+// (workspace vs. single project) that a future formatter will walk.
+func fmtCommand(cwd string, workspace bool) {
+	if !workspace {
+		fmt.Println("bituin fmt: no formatter registered yet for this project")
+		return
+	}
+
+	root, ok := FindWorkspaceRoot(cwd)
+	if !ok {
+		fmt.Println("Error: no bituin.workspace.toml found above the current directory")
+		os.Exit(1)
+	}
+	ws, err := LoadWorkspace(root)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, memberDir := range ws.Members {
+		fmt.Printf("bituin fmt: no formatter registered yet for %s\n", ws.memberName(memberDir))
+	}
+}
+
+// pathDependencyRe matches a `name = { path = "../other-member" }`
+// dependency entry, used to resolve workspace-local packages without
+// hitting the registry.
+var pathDependencyRe = regexp.MustCompile(`(?m)^\s*([\w-]+)\s*=\s*\{\s*path\s*=\s*"([^"]+)"\s*\}\s*$`)
+
+// ReadPathDependencies extracts `name = { path = "..." }` entries from a
+// bituin.toml's [dependencies] table.
+func ReadPathDependencies(configContent string) map[string]string {
+	deps := map[string]string{}
+	section := dependenciesSectionRe.FindStringSubmatch(configContent)
+	if section == nil {
+		return deps
+	}
+	for _, m := range pathDependencyRe.FindAllStringSubmatch(section[1], -1) {
+		deps[m[1]] = m[2]
+	}
+	return deps
+}
+
+// installPathDependencies copies each workspace-local path dependency
+// into microscript_modules/<name>/local/, bypassing the registry
+// entirely, and records it in the shared workspace lockfile.
+func installPathDependencies(projectRoot string, pathDeps map[string]string) error {
+	if len(pathDeps) == 0 {
+		return nil
+	}
+
+	root, ok := FindWorkspaceRoot(projectRoot)
+	if !ok {
+		root = projectRoot
+	}
+	lock, err := ReadLockfile(root)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range pathDeps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rel := pathDeps[name]
+		source := filepath.Join(projectRoot, rel)
+		if _, err := os.Stat(source); err != nil {
+			return fmt.Errorf("path dependency %q not found at %s", name, source)
+		}
+
+		dest := filepath.Join(projectRoot, MODULES_DIR, name, "local")
+		if err := os.RemoveAll(dest); err != nil {
+			return fmt.Errorf("clearing %s: %w", dest, err)
+		}
+		if err := copyDir(source, dest); err != nil {
+			return fmt.Errorf("linking path dependency %q: %w", name, err)
+		}
+
+		lock.Put(LockedPackage{Name: name, Version: "local", SHA256: "path:" + rel})
+		fmt.Printf("Using local %s (%s)\n", name, strings.TrimSuffix(rel, "/"))
+	}
+
+	return lock.Write(root)
+}
+
+// copyDir recursively copies src into dst, creating dst as needed.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode())
+	})
+}
@@ -0,0 +1,81 @@
+/*
+ * Bituin (Filipino for "star") - The MicroScript Package Manager
+ * Copyright (c) 2025 Cyril John Magayaga
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeMember(t *testing.T, root, name string) string {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll(%s): %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bituin.toml"), []byte("name = \""+name+"\"\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return dir
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeMember(t, root, "pkg-a")
+	writeMember(t, root, "pkg-b")
+
+	workspaceToml := "[workspace]\nmembers = [\"pkg-a\", \"pkg-b\"]\n"
+	if err := os.WriteFile(filepath.Join(root, WORKSPACE_TOML), []byte(workspaceToml), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ws, err := LoadWorkspace(root)
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	if len(ws.Members) != 2 {
+		t.Fatalf("len(Members) = %d, want 2", len(ws.Members))
+	}
+	if ws.memberName(ws.Members[0]) != "pkg-a" || ws.memberName(ws.Members[1]) != "pkg-b" {
+		t.Errorf("Members = %v, want [pkg-a pkg-b] (sorted)", ws.Members)
+	}
+}
+
+func TestLoadWorkspaceSkipsNonMembers(t *testing.T) {
+	root := t.TempDir()
+	writeMember(t, root, "pkg-a")
+	if err := os.MkdirAll(filepath.Join(root, "not-a-package"), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	workspaceToml := "members = [\"pkg-a\", \"not-a-package\"]\n"
+	if err := os.WriteFile(filepath.Join(root, WORKSPACE_TOML), []byte(workspaceToml), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ws, err := LoadWorkspace(root)
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	if len(ws.Members) != 1 {
+		t.Fatalf("len(Members) = %d, want 1 (dir without bituin.toml excluded)", len(ws.Members))
+	}
+}
+
+func TestFindMember(t *testing.T) {
+	root := t.TempDir()
+	writeMember(t, root, "pkg-a")
+
+	ws := &Workspace{Root: root, Members: []string{filepath.Join(root, "pkg-a")}}
+
+	if _, err := ws.findMember("pkg-a"); err != nil {
+		t.Errorf("findMember(pkg-a): %v", err)
+	}
+	if _, err := ws.findMember("missing"); err == nil {
+		t.Error("findMember(missing): expected error, got nil")
+	}
+}